@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+type deployCmd struct {
+	Dir string
+}
+
+// run registers webhooks declared in the project's cloud/functions.yaml: for
+// every function with a url, it creates or updates the matching
+// /1/hooks/functions webhook, so a url in functions.yaml never needs a
+// separate "parse functions create" step. It does not upload or otherwise
+// deploy Cloud Code itself.
+func (d *deployCmd) run(e *env, ctx *context) error {
+	cloudCodeDir := d.Dir
+	if cloudCodeDir == "" {
+		cloudCodeDir = "cloud"
+	}
+	cloudCodeDir = filepath.Join(e.Root, cloudCodeDir)
+
+	if err := registerFunctionWebhooks(e, cloudCodeDir); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(e.Out, "Registered webhooks declared in functions.yaml.")
+	return nil
+}
+
+func newDeployCmd(e *env) *cobra.Command {
+	d := deployCmd{}
+	c := &cobra.Command{
+		Use:   "deploy",
+		Short: "Registers webhooks declared in functions.yaml",
+		Long: `Registers webhooks declared in functions.yaml.
+
+Reads cloud/functions.yaml and registers or updates a /1/hooks/functions
+webhook for every function that declares a url, so functions with a url
+never need a separate "parse functions create" step. This does not upload
+or otherwise deploy your Cloud Code.`,
+		Run: runWithClient(e, d.run),
+	}
+	c.Flags().StringVar(&d.Dir, "dir", "", `the Cloud Code directory to read functions.yaml from (defaults to "cloud")`)
+
+	return c
+}
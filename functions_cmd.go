@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -12,9 +13,9 @@ import (
 )
 
 type functionHook struct {
-	FunctionName string `json:"functionName,omitempty"`
-	URL          string `json:"url,omitempty"`
-	Warning      string `json:"warning,omitempty"`
+	FunctionName string `json:"functionName,omitempty" yaml:"functionName,omitempty"`
+	URL          string `json:"url,omitempty" yaml:"url,omitempty"`
+	Warning      string `json:"warning,omitempty" yaml:"warning,omitempty"`
 }
 
 func (f functionHook) String() string {
@@ -27,27 +28,43 @@ func (f functionHook) String() string {
 type functionHooksCmd struct {
 	All      bool
 	Function *functionHook
+
+	// Name and URL let the create/edit/delete/read subcommands run
+	// non-interactively by supplying the --name/--url flags instead of
+	// answering the matching prompt. Yes bypasses the delete
+	// confirmation prompt, and Output selects "text" (default) or
+	// "json" for the read/list subcommands, so scripts can pipe the
+	// result into jq.
+	Name   string
+	URL    string
+	Yes    bool
+	Output string
 }
 
-func readFunctionName(e *env) (*functionHook, error) {
-	var f functionHook
-	fmt.Fprintf(e.Out, "Please enter the function name: ")
-	fmt.Fscanf(e.In, "%s\n", &f.FunctionName)
+func readFunctionName(e *env, name string) (*functionHook, error) {
+	f := functionHook{FunctionName: name}
+	if f.FunctionName == "" {
+		fmt.Fprintf(e.Out, "Please enter the function name: ")
+		fmt.Fscanf(e.In, "%s\n", &f.FunctionName)
+	}
 	if f.FunctionName == "" {
 		return nil, errors.New("Function name cannot be empty")
 	}
 	return &f, nil
 }
 
-func readFunctionParams(e *env) (*functionHook, error) {
-	f, err := readFunctionName(e)
+func readFunctionParams(e *env, name, urlStr string) (*functionHook, error) {
+	f, err := readFunctionName(e, name)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Fprint(e.Out, "URL: https://")
-	fmt.Fscanf(e.In, "%s\n", &f.URL)
-	f.URL = "https://" + f.URL
+	f.URL = urlStr
+	if f.URL == "" {
+		fmt.Fprint(e.Out, "URL: https://")
+		fmt.Fscanf(e.In, "%s\n", &f.URL)
+		f.URL = "https://" + f.URL
+	}
 	if err := validateURL(f.URL); err != nil {
 		return nil, err
 	}
@@ -58,7 +75,7 @@ func readFunctionParams(e *env) (*functionHook, error) {
 const defaultFunctionsURL = "/1/hooks/functions"
 
 func (h *functionHooksCmd) functionHooksCreate(e *env, ctx *context) error {
-	params, err := readFunctionParams(e)
+	params, err := readFunctionParams(e, h.Name, h.URL)
 	if err != nil {
 		return err
 	}
@@ -87,7 +104,7 @@ func (h *functionHooksCmd) functionHooksRead(e *env, ctx *context) error {
 	u := defaultFunctionsURL
 	var function *functionHook
 	if !h.All {
-		funct, err := readFunctionName(e)
+		funct, err := readFunctionName(e, h.Name)
 		if err != nil {
 			return err
 		}
@@ -106,6 +123,13 @@ func (h *functionHooksCmd) functionHooksRead(e *env, ctx *context) error {
 	if err != nil {
 		return err
 	}
+
+	if h.Output == "json" {
+		enc := json.NewEncoder(e.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res.Results)
+	}
+
 	var output []string
 	for _, function := range res.Results {
 		output = append(output, function.String())
@@ -126,7 +150,7 @@ func (h *functionHooksCmd) functionHooksRead(e *env, ctx *context) error {
 }
 
 func (h *functionHooksCmd) functionHooksUpdate(e *env, ctx *context) error {
-	params, err := readFunctionParams(e)
+	params, err := readFunctionParams(e, h.Name, h.URL)
 	if err != nil {
 		return err
 	}
@@ -153,7 +177,7 @@ func (h *functionHooksCmd) functionHooksUpdate(e *env, ctx *context) error {
 }
 
 func (h *functionHooksCmd) functionHooksDelete(e *env, ctx *context) error {
-	params, err := readFunctionName(e)
+	params, err := readFunctionName(e, h.Name)
 	if err != nil {
 		return err
 	}
@@ -168,7 +192,7 @@ func (h *functionHooksCmd) functionHooksDelete(e *env, ctx *context) error {
 	)
 
 	var res functionHook
-	if getConfirmation(confirmMessage, e) {
+	if h.Yes || getConfirmation(confirmMessage, e) {
 		_, err = e.ParseAPIClient.Put(functionsURL, map[string]interface{}{"__op": "Delete"}, &res)
 		if err != nil {
 			return err
@@ -197,6 +221,7 @@ func newFunctionHooksCmd(e *env) *cobra.Command {
 		Long:  "List cloud code functions and function webhooks",
 		Run:   runWithClient(e, h.functionHooks),
 	}
+	c.Flags().StringVar(&h.Output, "output", "text", `output format: "text" or "json"`)
 
 	createCmd := &cobra.Command{
 		Use:   "create",
@@ -204,6 +229,8 @@ func newFunctionHooksCmd(e *env) *cobra.Command {
 		Long:  "Create a function webhook",
 		Run:   runWithClient(e, h.functionHooksCreate),
 	}
+	createCmd.Flags().StringVar(&h.Name, "name", "", "the function name")
+	createCmd.Flags().StringVar(&h.URL, "url", "", "the webhook URL")
 	c.AddCommand(createCmd)
 
 	changeCmd := &cobra.Command{
@@ -212,6 +239,8 @@ func newFunctionHooksCmd(e *env) *cobra.Command {
 		Long:  "Edit the URL of a function webhook",
 		Run:   runWithClient(e, h.functionHooksUpdate),
 	}
+	changeCmd.Flags().StringVar(&h.Name, "name", "", "the function name")
+	changeCmd.Flags().StringVar(&h.URL, "url", "", "the webhook URL")
 	c.AddCommand(changeCmd)
 
 	deleteCmd := &cobra.Command{
@@ -220,7 +249,19 @@ func newFunctionHooksCmd(e *env) *cobra.Command {
 		Long:  "Delete a function webhook",
 		Run:   runWithClient(e, h.functionHooksDelete),
 	}
+	deleteCmd.Flags().StringVar(&h.Name, "name", "", "the function name")
+	deleteCmd.Flags().BoolVar(&h.Yes, "yes", false, "skip the delete confirmation prompt")
 	c.AddCommand(deleteCmd)
 
+	readCmd := &cobra.Command{
+		Use:   "read",
+		Short: "Show a single function webhook",
+		Long:  "Show a single function webhook",
+		Run:   runWithClient(e, h.functionHooksRead),
+	}
+	readCmd.Flags().StringVar(&h.Name, "name", "", "the function name")
+	readCmd.Flags().StringVar(&h.Output, "output", "text", `output format: "text" or "json"`)
+	c.AddCommand(readCmd)
+
 	return c
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/facebookgo/stackerr"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	minFunctionTimeout     = 1
+	maxFunctionTimeout     = 540
+	defaultFunctionTimeout = 60
+
+	defaultFunctionMemory = 128
+
+	functionsManifestFilename = "functions.yaml"
+)
+
+// validFunctionMemory are the fixed memory allocations the Parse Cloud Code
+// runtime supports for a function, in MB.
+var validFunctionMemory = map[int]bool{
+	128:  true,
+	256:  true,
+	512:  true,
+	1024: true,
+	2048: true,
+}
+
+// cloudFunction describes a single cloud function's deployment metadata, as
+// declared in a project's cloud/functions.yaml: its entry point in main.js,
+// its memory/timeout limits, and, optionally, the webhook URL it should be
+// registered under on deploy.
+type cloudFunction struct {
+	Name       string `yaml:"name"`
+	EntryPoint string `yaml:"entryPoint"`
+	Memory     int    `yaml:"memory,omitempty"`
+	Timeout    int    `yaml:"timeout,omitempty"`
+	URL        string `yaml:"url,omitempty"`
+}
+
+type functionsManifest struct {
+	Functions []cloudFunction `yaml:"functions"`
+}
+
+func (f *cloudFunction) setDefaults() error {
+	if f.Memory == 0 {
+		f.Memory = defaultFunctionMemory
+	}
+	if !validFunctionMemory[f.Memory] {
+		return stackerr.Newf(
+			"memory for function %q must be one of 128, 256, 512, 1024, or 2048 MB, got %d",
+			f.Name, f.Memory,
+		)
+	}
+	if f.Timeout == 0 {
+		f.Timeout = defaultFunctionTimeout
+	}
+	if f.Timeout < minFunctionTimeout || f.Timeout > maxFunctionTimeout {
+		return stackerr.Newf(
+			"timeout for function %q must be between %d and %d seconds, got %d",
+			f.Name, minFunctionTimeout, maxFunctionTimeout, f.Timeout,
+		)
+	}
+	return nil
+}
+
+// readFunctionsManifest reads functions.yaml out of cloudCodeDir. A missing
+// file is not an error: projects created before this manifest existed, or
+// that don't need per-function metadata, simply have no functions to register
+// webhooks for. Memory and timeout are defaulted and validated here; nothing
+// in this package sends them to the server.
+func readFunctionsManifest(cloudCodeDir string) (*functionsManifest, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(cloudCodeDir, functionsManifestFilename))
+	if os.IsNotExist(err) {
+		return &functionsManifest{}, nil
+	}
+	if err != nil {
+		return nil, stackerr.Wrap(err)
+	}
+	var manifest functionsManifest
+	if err := yaml.Unmarshal(contents, &manifest); err != nil {
+		return nil, stackerr.Wrap(err)
+	}
+	for i := range manifest.Functions {
+		if err := manifest.Functions[i].setDefaults(); err != nil {
+			return nil, err
+		}
+	}
+	return &manifest, nil
+}
+
+// registerFunctionWebhooks reads the functions manifest out of cloudCodeDir
+// and, for every function that declares a url, creates or updates the
+// matching /1/hooks/functions webhook. This runs as part of "parse deploy"
+// so a function with a url in functions.yaml never needs a separate
+// "parse functions create" step.
+func registerFunctionWebhooks(e *env, cloudCodeDir string) error {
+	manifest, err := readFunctionsManifest(cloudCodeDir)
+	if err != nil {
+		return err
+	}
+
+	apply := &hooksApplyCmd{}
+	existing, err := apply.currentFunctions(e)
+	if err != nil {
+		return err
+	}
+	existingByName := make(map[string]*functionHook)
+	for _, hook := range existing {
+		existingByName[hook.FunctionName] = hook
+	}
+
+	for _, fn := range manifest.Functions {
+		if fn.URL == "" {
+			continue
+		}
+		hook := &functionHook{FunctionName: fn.Name, URL: fn.URL}
+		op := hookOperation{Method: "POST", Function: hook}
+		if have, ok := existingByName[fn.Name]; ok {
+			if have.URL == fn.URL {
+				continue
+			}
+			op.Method = "PUT"
+		}
+		if err := apply.apply(op, e); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.Out, "Registered webhook for function %q -> %q\n", fn.Name, fn.URL)
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+
+	"github.com/facebookgo/stackerr"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// hookManifest is the declarative description of the webhooks a project
+// wants configured, as read from a "hooks.yaml" (or equivalent JSON) file.
+type hookManifest struct {
+	Functions []functionHook `json:"functions,omitempty" yaml:"functions,omitempty"`
+	Triggers  []triggerHook  `json:"triggers,omitempty" yaml:"triggers,omitempty"`
+}
+
+// hookOperation is a single reconciling action computed by diffing a
+// hookManifest against the hooks the server currently has registered.
+type hookOperation struct {
+	Method   string
+	Function *functionHook
+	Trigger  *triggerHook
+
+	// PreviousURL is the URL a PUT operation is replacing. diff populates
+	// it from the server's current state so invert() can restore it if
+	// the operation needs to be rolled back.
+	PreviousURL string
+}
+
+func (op hookOperation) String() string {
+	switch {
+	case op.Function != nil:
+		return fmt.Sprintf("%s function %q -> %q", op.Method, op.Function.FunctionName, op.Function.URL)
+	case op.Trigger != nil:
+		return fmt.Sprintf("%s trigger %q on class %q -> %q", op.Method, op.Trigger.TriggerName, op.Trigger.ClassName, op.Trigger.URL)
+	default:
+		return op.Method
+	}
+}
+
+// invert returns the operation that undoes op, used to roll back an
+// already-applied plan when a later operation in the same run fails.
+func (op hookOperation) invert() hookOperation {
+	switch op.Method {
+	case "POST":
+		inverted := op
+		inverted.Method = "DELETE"
+		return inverted
+	case "DELETE":
+		inverted := op
+		inverted.Method = "POST"
+		return inverted
+	case "PUT":
+		// A PUT is its own inverse, pointed back at PreviousURL instead
+		// of the URL it just set.
+		inverted := op
+		if inverted.Function != nil {
+			reverted := *inverted.Function
+			reverted.URL = op.PreviousURL
+			inverted.Function = &reverted
+		}
+		if inverted.Trigger != nil {
+			reverted := *inverted.Trigger
+			reverted.URL = op.PreviousURL
+			inverted.Trigger = &reverted
+		}
+		return inverted
+	default:
+		return op
+	}
+}
+
+type hooksApplyCmd struct {
+	File   string
+	DryRun bool
+	Prune  bool
+	Yes    bool
+}
+
+func (h *hooksApplyCmd) readManifest() (*hookManifest, error) {
+	contents, err := ioutil.ReadFile(h.File)
+	if err != nil {
+		return nil, stackerr.Wrap(err)
+	}
+	var manifest hookManifest
+	if err := yaml.Unmarshal(contents, &manifest); err != nil {
+		return nil, stackerr.Wrap(err)
+	}
+	return &manifest, nil
+}
+
+func (h *hooksApplyCmd) currentFunctions(e *env) ([]*functionHook, error) {
+	functionsURL, err := url.Parse(defaultFunctionsURL)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Results []*functionHook `json:"results,omitempty"`
+	}
+	if _, err := e.ParseAPIClient.Get(functionsURL, &res); err != nil {
+		return nil, err
+	}
+	return res.Results, nil
+}
+
+func (h *hooksApplyCmd) currentTriggers(e *env) ([]*triggerHook, error) {
+	triggersURL, err := url.Parse(defaultTriggersURL)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Results []*triggerHook `json:"results,omitempty"`
+	}
+	if _, err := e.ParseAPIClient.Get(triggersURL, &res); err != nil {
+		return nil, err
+	}
+	return res.Results, nil
+}
+
+// diffFunctionOps computes the add/edit/prune operations needed to make
+// existing match wanted. It's split out from diff so the reconciliation
+// logic can be table-tested without a live ParseAPIClient.
+func diffFunctionOps(existing []*functionHook, wanted []functionHook, prune bool) []hookOperation {
+	var ops []hookOperation
+
+	seen := make(map[string]bool)
+	for _, want := range wanted {
+		want := want
+		seen[want.FunctionName] = true
+		var found *functionHook
+		for _, have := range existing {
+			if have.FunctionName == want.FunctionName {
+				found = have
+				break
+			}
+		}
+		switch {
+		case found == nil:
+			ops = append(ops, hookOperation{Method: "POST", Function: &want})
+		case found.URL != want.URL:
+			ops = append(ops, hookOperation{Method: "PUT", Function: &want, PreviousURL: found.URL})
+		}
+	}
+	if prune {
+		for _, have := range existing {
+			if !seen[have.FunctionName] {
+				ops = append(ops, hookOperation{Method: "DELETE", Function: have})
+			}
+		}
+	}
+
+	return ops
+}
+
+// diffTriggerOps is diffFunctionOps's trigger-hook counterpart; see it for
+// the rules applied.
+func diffTriggerOps(existing []*triggerHook, wanted []triggerHook, prune bool) []hookOperation {
+	var ops []hookOperation
+
+	seen := make(map[string]bool)
+	for _, want := range wanted {
+		want := want
+		key := want.ClassName + "/" + want.TriggerName
+		seen[key] = true
+		var found *triggerHook
+		for _, have := range existing {
+			if have.ClassName == want.ClassName && have.TriggerName == want.TriggerName {
+				found = have
+				break
+			}
+		}
+		switch {
+		case found == nil:
+			ops = append(ops, hookOperation{Method: "POST", Trigger: &want})
+		case found.URL != want.URL:
+			ops = append(ops, hookOperation{Method: "PUT", Trigger: &want, PreviousURL: found.URL})
+		}
+	}
+	if prune {
+		for _, have := range existing {
+			key := have.ClassName + "/" + have.TriggerName
+			if !seen[key] {
+				ops = append(ops, hookOperation{Method: "DELETE", Trigger: have})
+			}
+		}
+	}
+
+	return ops
+}
+
+// diff computes the ordered list of operations needed to make the server's
+// webhook state match the manifest: edits for hooks that exist but point to
+// a different URL, creates for hooks missing entirely, and — when prune is
+// set — deletes for server-side hooks absent from the manifest.
+func (h *hooksApplyCmd) diff(e *env) ([]hookOperation, error) {
+	manifest, err := h.readManifest()
+	if err != nil {
+		return nil, err
+	}
+	existingFunctions, err := h.currentFunctions(e)
+	if err != nil {
+		return nil, err
+	}
+	existingTriggers, err := h.currentTriggers(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []hookOperation
+	ops = append(ops, diffFunctionOps(existingFunctions, manifest.Functions, h.Prune)...)
+	ops = append(ops, diffTriggerOps(existingTriggers, manifest.Triggers, h.Prune)...)
+	return ops, nil
+}
+
+func (h *hooksApplyCmd) apply(op hookOperation, e *env) error {
+	switch {
+	case op.Function != nil:
+		functionsURL, err := url.Parse(path.Join(defaultFunctionsURL, op.Function.FunctionName))
+		if err != nil {
+			return err
+		}
+		var res functionHook
+		switch op.Method {
+		case "POST":
+			base, err := url.Parse(defaultFunctionsURL)
+			if err != nil {
+				return err
+			}
+			_, err = e.ParseAPIClient.Post(base, op.Function, &res)
+			return err
+		case "PUT":
+			_, err := e.ParseAPIClient.Put(functionsURL, &functionHook{URL: op.Function.URL}, &res)
+			return err
+		case "DELETE":
+			_, err := e.ParseAPIClient.Put(functionsURL, map[string]interface{}{"__op": "Delete"}, &res)
+			return err
+		}
+	case op.Trigger != nil:
+		triggersURL, err := url.Parse(path.Join(defaultTriggersURL, op.Trigger.ClassName, op.Trigger.TriggerName))
+		if err != nil {
+			return err
+		}
+		var res triggerHook
+		switch op.Method {
+		case "POST":
+			base, err := url.Parse(defaultTriggersURL)
+			if err != nil {
+				return err
+			}
+			_, err = e.ParseAPIClient.Post(base, op.Trigger, &res)
+			return err
+		case "PUT":
+			_, err := e.ParseAPIClient.Put(triggersURL, &triggerHook{URL: op.Trigger.URL}, &res)
+			return err
+		case "DELETE":
+			_, err := e.ParseAPIClient.Put(triggersURL, map[string]interface{}{"__op": "Delete"}, &res)
+			return err
+		}
+	}
+	return stackerr.Newf("unrecognized hook operation: %v", op)
+}
+
+func (h *hooksApplyCmd) run(e *env, ctx *context) error {
+	ops, err := h.diff(e)
+	if err != nil {
+		return err
+	}
+
+	if len(ops) == 0 {
+		fmt.Fprintln(e.Out, "Webhooks are already up to date with the manifest.")
+		return nil
+	}
+
+	fmt.Fprintln(e.Out, "The following changes will be made to your webhooks:")
+	for _, op := range ops {
+		fmt.Fprintf(e.Out, "  %s\n", op)
+	}
+
+	if h.DryRun {
+		return nil
+	}
+
+	if !h.Yes && !getConfirmation("Apply these changes? (y/n): ", e) {
+		return nil
+	}
+
+	var applied []hookOperation
+	for _, op := range ops {
+		if err := h.apply(op, e); err != nil {
+			fmt.Fprintf(e.Err, "Failed to apply %s: %s\n", op, err)
+			fmt.Fprintln(e.Err, "Rolling back already-applied changes...")
+			for i := len(applied) - 1; i >= 0; i-- {
+				if rollbackErr := h.apply(applied[i].invert(), e); rollbackErr != nil {
+					fmt.Fprintf(e.Err, "Failed to roll back %s: %s\n", applied[i], rollbackErr)
+				}
+			}
+			return err
+		}
+		applied = append(applied, op)
+	}
+
+	fmt.Fprintln(e.Out, "Successfully applied all webhook changes.")
+	return nil
+}
+
+func newHooksApplyCmd(e *env) *cobra.Command {
+	h := hooksApplyCmd{File: "hooks.yaml"}
+
+	c := &cobra.Command{
+		Use:   "apply",
+		Short: "Sync function and trigger webhooks from a manifest file",
+		Long: `Sync function and trigger webhooks from a manifest file.
+
+Reads a YAML (or JSON) manifest describing the desired set of function and
+trigger webhooks, diffs it against what the server currently has registered,
+and applies the create/edit/delete operations needed to reconcile.`,
+		Run: runWithClient(e, h.run),
+	}
+	c.Flags().StringVarP(&h.File, "file", "f", h.File, "path to the hooks manifest")
+	c.Flags().BoolVar(&h.DryRun, "dry-run", false, "print the plan without applying it")
+	c.Flags().BoolVar(&h.Prune, "prune", false, "delete server-side hooks that are absent from the manifest")
+	c.Flags().BoolVar(&h.Yes, "yes", false, "skip the apply confirmation prompt")
+
+	return c
+}
@@ -0,0 +1,214 @@
+package main
+
+import "testing"
+
+func TestDiffFunctionOps(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []*functionHook
+		wanted   []functionHook
+		prune    bool
+		want     []hookOperation
+	}{
+		{
+			name:     "create missing function",
+			existing: nil,
+			wanted:   []functionHook{{FunctionName: "hello", URL: "https://example.com/hello"}},
+			want: []hookOperation{
+				{Method: "POST", Function: &functionHook{FunctionName: "hello", URL: "https://example.com/hello"}},
+			},
+		},
+		{
+			name:     "edit function with a different URL",
+			existing: []*functionHook{{FunctionName: "hello", URL: "https://old.example.com/hello"}},
+			wanted:   []functionHook{{FunctionName: "hello", URL: "https://new.example.com/hello"}},
+			want: []hookOperation{
+				{
+					Method:      "PUT",
+					Function:    &functionHook{FunctionName: "hello", URL: "https://new.example.com/hello"},
+					PreviousURL: "https://old.example.com/hello",
+				},
+			},
+		},
+		{
+			name:     "matching function is left alone",
+			existing: []*functionHook{{FunctionName: "hello", URL: "https://example.com/hello"}},
+			wanted:   []functionHook{{FunctionName: "hello", URL: "https://example.com/hello"}},
+			want:     nil,
+		},
+		{
+			name:     "extra function left alone without prune",
+			existing: []*functionHook{{FunctionName: "extra", URL: "https://example.com/extra"}},
+			wanted:   nil,
+			prune:    false,
+			want:     nil,
+		},
+		{
+			name:     "extra function deleted with prune",
+			existing: []*functionHook{{FunctionName: "extra", URL: "https://example.com/extra"}},
+			wanted:   nil,
+			prune:    true,
+			want: []hookOperation{
+				{Method: "DELETE", Function: &functionHook{FunctionName: "extra", URL: "https://example.com/extra"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffFunctionOps(tt.existing, tt.wanted, tt.prune)
+			assertOpsEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestDiffTriggerOps(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []*triggerHook
+		wanted   []triggerHook
+		prune    bool
+		want     []hookOperation
+	}{
+		{
+			name:     "create missing trigger",
+			existing: nil,
+			wanted:   []triggerHook{{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"}},
+			want: []hookOperation{
+				{Method: "POST", Trigger: &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"}},
+			},
+		},
+		{
+			name: "edit trigger with a different URL",
+			existing: []*triggerHook{
+				{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://old.example.com/a"},
+			},
+			wanted: []triggerHook{
+				{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://new.example.com/a"},
+			},
+			want: []hookOperation{
+				{
+					Method:      "PUT",
+					Trigger:     &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://new.example.com/a"},
+					PreviousURL: "https://old.example.com/a",
+				},
+			},
+		},
+		{
+			name: "same class, different trigger name are distinct",
+			existing: []*triggerHook{
+				{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"},
+			},
+			wanted: []triggerHook{
+				{ClassName: "Comment", TriggerName: "afterSave", URL: "https://example.com/b"},
+			},
+			want: []hookOperation{
+				{Method: "POST", Trigger: &triggerHook{ClassName: "Comment", TriggerName: "afterSave", URL: "https://example.com/b"}},
+			},
+		},
+		{
+			name: "extra trigger deleted with prune",
+			existing: []*triggerHook{
+				{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"},
+			},
+			wanted: nil,
+			prune:  true,
+			want: []hookOperation{
+				{Method: "DELETE", Trigger: &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffTriggerOps(tt.existing, tt.wanted, tt.prune)
+			assertOpsEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestHookOperationInvert(t *testing.T) {
+	tests := []struct {
+		name string
+		op   hookOperation
+		want hookOperation
+	}{
+		{
+			name: "POST inverts to DELETE",
+			op:   hookOperation{Method: "POST", Function: &functionHook{FunctionName: "hello", URL: "https://example.com/hello"}},
+			want: hookOperation{Method: "DELETE", Function: &functionHook{FunctionName: "hello", URL: "https://example.com/hello"}},
+		},
+		{
+			name: "DELETE inverts to POST",
+			op:   hookOperation{Method: "DELETE", Trigger: &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"}},
+			want: hookOperation{Method: "POST", Trigger: &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://example.com/a"}},
+		},
+		{
+			name: "PUT on a function restores PreviousURL",
+			op: hookOperation{
+				Method:      "PUT",
+				Function:    &functionHook{FunctionName: "hello", URL: "https://new.example.com/hello"},
+				PreviousURL: "https://old.example.com/hello",
+			},
+			want: hookOperation{
+				Method:      "PUT",
+				Function:    &functionHook{FunctionName: "hello", URL: "https://old.example.com/hello"},
+				PreviousURL: "https://old.example.com/hello",
+			},
+		},
+		{
+			name: "PUT on a trigger restores PreviousURL",
+			op: hookOperation{
+				Method:      "PUT",
+				Trigger:     &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://new.example.com/a"},
+				PreviousURL: "https://old.example.com/a",
+			},
+			want: hookOperation{
+				Method:      "PUT",
+				Trigger:     &triggerHook{ClassName: "Comment", TriggerName: "beforeSave", URL: "https://old.example.com/a"},
+				PreviousURL: "https://old.example.com/a",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op.invert()
+			if got.Method != tt.want.Method {
+				t.Errorf("Method = %q, want %q", got.Method, tt.want.Method)
+			}
+			if (got.Function == nil) != (tt.want.Function == nil) {
+				t.Fatalf("Function = %v, want %v", got.Function, tt.want.Function)
+			}
+			if got.Function != nil && *got.Function != *tt.want.Function {
+				t.Errorf("Function = %+v, want %+v", *got.Function, *tt.want.Function)
+			}
+			if (got.Trigger == nil) != (tt.want.Trigger == nil) {
+				t.Fatalf("Trigger = %v, want %v", got.Trigger, tt.want.Trigger)
+			}
+			if got.Trigger != nil && *got.Trigger != *tt.want.Trigger {
+				t.Errorf("Trigger = %+v, want %+v", *got.Trigger, *tt.want.Trigger)
+			}
+		})
+	}
+}
+
+func assertOpsEqual(t *testing.T, got, want []hookOperation) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d ops, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range got {
+		g, w := got[i], want[i]
+		if g.Method != w.Method || g.PreviousURL != w.PreviousURL {
+			t.Errorf("op[%d] = %+v, want %+v", i, g, w)
+			continue
+		}
+		if (g.Function == nil) != (w.Function == nil) || (g.Function != nil && *g.Function != *w.Function) {
+			t.Errorf("op[%d].Function = %+v, want %+v", i, g.Function, w.Function)
+		}
+		if (g.Trigger == nil) != (w.Trigger == nil) || (g.Trigger != nil && *g.Trigger != *w.Trigger) {
+			t.Errorf("op[%d].Trigger = %+v, want %+v", i, g.Trigger, w.Trigger)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import "github.com/spf13/cobra"
+
+// newHooksCmd groups the declarative and development-time webhook commands
+// under "parse hooks", alongside the interactive "parse functions" and
+// "parse triggers" commands that manage the same /1/hooks endpoints.
+func newHooksCmd(e *env) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage function and trigger webhooks",
+		Long:  "Manage function and trigger webhooks",
+	}
+
+	c.AddCommand(newHooksApplyCmd(e))
+	c.AddCommand(newHooksServeCmd(e))
+
+	return c
+}
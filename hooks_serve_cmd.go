@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/facebookgo/stackerr"
+	"github.com/spf13/cobra"
+)
+
+// hookPayload is the body Parse sends to a function or trigger webhook.
+type hookPayload struct {
+	FunctionName string          `json:"functionName,omitempty"`
+	TriggerName  string          `json:"triggerName,omitempty"`
+	Params       json.RawMessage `json:"params,omitempty"`
+}
+
+type hooksServeCmd struct {
+	Port      int
+	TunnelURL string
+}
+
+// restoreHook pairs a webhook with the operation that points it back at its
+// original URL once the local dev server stops.
+type restoreHook struct {
+	op hookOperation
+}
+
+// handler logs incoming hook calls but does not execute cloud/main.js: this
+// package has no JavaScript runtime to run it in. It reports that plainly
+// with a 501 rather than faking a success response, so a developer watching
+// their Cloud Code misbehave doesn't mistake this for a passing call.
+func (h *hooksServeCmd) handler(e *env) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var payload hookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		name := payload.FunctionName
+		if name == "" {
+			name = payload.TriggerName
+		}
+		fmt.Fprintf(e.Out, "Received hook call for %q\n", name)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotImplemented)
+		fmt.Fprintf(w, `{"error":%q}`, "parse hooks serve does not execute cloud/main.js yet; this call was logged but not run")
+	})
+	return mux
+}
+
+// retarget points every currently-registered function and trigger webhook at
+// tunnelURL, skipping Cloud Code entries that have no URL, returning the operations that undo the change so the caller can
+// put the original URLs back once the dev server stops.
+func (h *hooksServeCmd) retarget(e *env, apply *hooksApplyCmd, tunnelURL string) ([]restoreHook, error) {
+	functions, err := apply.currentFunctions(e)
+	if err != nil {
+		return nil, err
+	}
+	triggers, err := apply.currentTriggers(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var restores []restoreHook
+	for _, fn := range functions {
+		if fn.URL == "" {
+			continue
+		}
+		original := fn.URL
+		op := hookOperation{Method: "PUT", Function: &functionHook{FunctionName: fn.FunctionName, URL: tunnelURL}}
+		if err := apply.apply(op, e); err != nil {
+			return restores, err
+		}
+		restores = append(restores, restoreHook{
+			op: hookOperation{Method: "PUT", Function: &functionHook{FunctionName: fn.FunctionName, URL: original}},
+		})
+	}
+	for _, tr := range triggers {
+		if tr.URL == "" {
+			continue
+		}
+		original := tr.URL
+		op := hookOperation{Method: "PUT", Trigger: &triggerHook{ClassName: tr.ClassName, TriggerName: tr.TriggerName, URL: tunnelURL}}
+		if err := apply.apply(op, e); err != nil {
+			return restores, err
+		}
+		restores = append(restores, restoreHook{
+			op: hookOperation{Method: "PUT", Trigger: &triggerHook{ClassName: tr.ClassName, TriggerName: tr.TriggerName, URL: original}},
+		})
+	}
+	return restores, nil
+}
+
+func (h *hooksServeCmd) restore(e *env, apply *hooksApplyCmd, restores []restoreHook) {
+	for _, r := range restores {
+		if err := apply.apply(r.op, e); err != nil {
+			fmt.Fprintf(e.Err, "Failed to restore webhook %s: %s\n", r.op, err)
+		}
+	}
+}
+
+func (h *hooksServeCmd) run(e *env, ctx *context) error {
+	if h.TunnelURL == "" {
+		return stackerr.New(
+			`"parse hooks serve" needs a public URL for Parse to reach your machine.
+Start your own tunnel (for example "ngrok http <port>") and pass its https
+URL with --tunnel-url.`,
+		)
+	}
+	if err := validateURL(h.TunnelURL); err != nil {
+		return err
+	}
+
+	apply := &hooksApplyCmd{}
+	restores, err := h.retarget(e, apply, h.TunnelURL)
+	if err != nil {
+		h.restore(e, apply, restores)
+		return err
+	}
+
+	server := &http.Server{Addr: fmt.Sprintf(":%d", h.Port), Handler: h.handler(e)}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		<-sig
+		fmt.Fprintln(e.Out, "\nShutting down and restoring original webhook URLs...")
+		h.restore(e, apply, restores)
+		server.Close()
+	}()
+
+	fmt.Fprintf(e.Out, "Logging cloud code hook calls on port %d, tunneled at %s\n", h.Port, h.TunnelURL)
+	fmt.Fprintln(e.Out, "Calls are not executed against cloud/main.js yet; see --help.")
+	fmt.Fprintln(e.Out, "Press Ctrl-C to stop and restore your original webhook URLs.")
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		h.restore(e, apply, restores)
+		return stackerr.Wrap(err)
+	}
+	return nil
+}
+
+func newHooksServeCmd(e *env) *cobra.Command {
+	h := hooksServeCmd{Port: 8377}
+	c := &cobra.Command{
+		Use:   "serve",
+		Short: "Log incoming webhook calls against a local tunnel",
+		Long: `Log incoming webhook calls against a local tunnel.
+
+Starts a local HTTP server, temporarily repoints every registered function
+and trigger webhook at a tunnel URL for as long as the server runs, and
+restores the original URLs on Ctrl-C. It logs each incoming hook call but
+does not yet execute cloud/main.js against it: that needs a JavaScript
+runtime this package doesn't have, so each call gets a 501 response rather
+than a faked success.`,
+		Run: runWithClient(e, h.run),
+	}
+	c.Flags().IntVar(&h.Port, "port", h.Port, "local port to listen on")
+	c.Flags().StringVar(&h.TunnelURL, "tunnel-url", "", "public https URL that tunnels to --port (e.g. from ngrok)")
+	return c
+}
@@ -13,15 +13,41 @@ import (
 
 type newCmd struct {
 	addApplication bool
+
+	// NewApp and ExistingApp let "parse new" run non-interactively by
+	// answering the "new or existing app" prompt via flags instead of
+	// stdin, and Dir similarly answers the Cloud Code directory prompt.
+	//
+	// AppName does NOT skip the app-selection/creation prompt: neither
+	// apps.createApp nor addCmd.selectApp accept a name to act on
+	// non-interactively yet. It only checks, after the prompt runs, that
+	// the resulting app matches — so a mismatch fails loudly instead of
+	// silently continuing against the wrong app.
+	NewApp      bool
+	ExistingApp bool
+	AppName     string
+	Dir         string
 }
 
 var newProjectFiles = []struct {
 	dirname, filename, content string
 }{
 	{"cloud", "main.js", sampleSource},
+	{"cloud", functionsManifestFilename, sampleFunctionsManifest},
 	{"public", "index.html", sampleHTML},
 }
 
+// sampleFunctionsManifest is the functions.yaml generated alongside the
+// sample main.js. It documents the memory/timeout knobs for the "hello"
+// function using the same defaults readFunctionsManifest applies when a
+// function omits them.
+const sampleFunctionsManifest = `functions:
+  - name: hello
+    entryPoint: hello
+    memory: 128
+    timeout: 60
+`
+
 func (n *newCmd) curlCommand(app *app) string {
 	return fmt.Sprintf(
 		`curl -X POST \
@@ -51,20 +77,22 @@ you can test that it works, with:
 }
 
 func (n *newCmd) getCloudCodeDir(e *env, appName string, isNew bool) (string, error) {
-	var cloudCodeDir string
-	fmt.Fprintf(e.Out,
-		`Awesome! Now it's time to setup some Cloud Code for the app: %q,
+	cloudCodeDir := n.Dir
+	if cloudCodeDir == "" {
+		fmt.Fprintf(e.Out,
+			`Awesome! Now it's time to setup some Cloud Code for the app: %q,
 Next we will create a directory to hold your Cloud Code.
 Please enter the name to use for this directory,
 or hit ENTER to use %q as the directory name.
 
 Directory Name: `,
-		appName,
-		appName,
-	)
+			appName,
+			appName,
+		)
 
-	fmt.Scanf("%s\n", &cloudCodeDir)
-	cloudCodeDir = strings.TrimSpace(cloudCodeDir)
+		fmt.Scanf("%s\n", &cloudCodeDir)
+		cloudCodeDir = strings.TrimSpace(cloudCodeDir)
+	}
 	if cloudCodeDir == "" {
 		cloudCodeDir = appName
 	}
@@ -135,6 +163,13 @@ func (n *newCmd) createConfigWithContent(path, content string) error {
 }
 
 func (n *newCmd) promptCreateNewApp(e *env) (string, error) {
+	switch {
+	case n.NewApp:
+		return "new", nil
+	case n.ExistingApp:
+		return "existing", nil
+	}
+
 	msg := `"new" and "existing" are the only valid options.
 Please try again ...`
 
@@ -246,6 +281,17 @@ func (n *newCmd) run(e *env) error {
 		}
 	}
 
+	// apps.createApp and addCmd.selectApp both still prompt interactively
+	// for which app to use; neither accepts a name to select or create
+	// non-interactively. Until that lands, --app-name at least guards
+	// against silently continuing with the wrong app when it's given.
+	if n.AppName != "" && app.Name != n.AppName {
+		return stackerr.Newf(
+			"app %q does not match --app-name %q",
+			app.Name, n.AppName,
+		)
+	}
+
 	e.Type = parseFormat
 
 	if err := n.setupSample(e, app, isNew); err != nil {
@@ -264,8 +310,18 @@ func newNewCmd(e *env) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "new",
 		Short: "Creates a new Parse app and adds Cloud Code to an existing Parse app",
-		Long:  `Creates a new Parse app and adds Cloud Code to an existing Parse app.`,
-		Run:   runNoArgs(e, nc.run),
+		Long: `Creates a new Parse app and adds Cloud Code to an existing Parse app.
+
+With --new or --existing and --dir provided, "parse new" skips the
+new/existing and directory prompts. It still prompts to create or select
+the app itself, so it does not run fully unattended. --app-name does not
+skip that prompt; it only verifies the app chosen or created interactively
+matches, and fails instead of continuing if it doesn't.`,
+		Run: runNoArgs(e, nc.run),
 	}
+	cmd.Flags().BoolVar(&nc.NewApp, "new", false, "create a new Parse app, skipping the new/existing prompt")
+	cmd.Flags().BoolVar(&nc.ExistingApp, "existing", false, "add Cloud Code to an existing Parse app, skipping the new/existing prompt")
+	cmd.Flags().StringVar(&nc.AppName, "app-name", "", "verify the app created or selected matches this name (does not skip the app prompt)")
+	cmd.Flags().StringVar(&nc.Dir, "dir", "", "the directory to create Cloud Code in")
 	return cmd
 }
@@ -0,0 +1,22 @@
+package main
+
+import "github.com/spf13/cobra"
+
+// newRootCmd assembles the top-level "parse" command from the individual
+// newXxxCmd constructors in this package. Each subcommand is only reachable
+// from the shipped binary once it is registered here.
+func newRootCmd(e *env) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "parse",
+		Short: "Command-line tool for Parse Cloud Code",
+		Long:  "Command-line tool for Parse Cloud Code",
+	}
+
+	root.AddCommand(newNewCmd(e))
+	root.AddCommand(newDeployCmd(e))
+	root.AddCommand(newFunctionHooksCmd(e))
+	root.AddCommand(newTriggerHooksCmd(e))
+	root.AddCommand(newHooksCmd(e))
+
+	return root
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type triggerHook struct {
+	ClassName   string `json:"className,omitempty" yaml:"className,omitempty"`
+	TriggerName string `json:"triggerName,omitempty" yaml:"triggerName,omitempty"`
+	URL         string `json:"url,omitempty" yaml:"url,omitempty"`
+	Warning     string `json:"warning,omitempty" yaml:"warning,omitempty"`
+}
+
+func (t triggerHook) String() string {
+	if t.URL != "" {
+		return fmt.Sprintf("Class: %q, Trigger: %q, URL: %q", t.ClassName, t.TriggerName, t.URL)
+	}
+	return fmt.Sprintf("Class: %q, Trigger: %q", t.ClassName, t.TriggerName)
+}
+
+// validTriggerNames are the trigger names the Parse hosted triggers API
+// accepts for a class-level webhook.
+var validTriggerNames = map[string]bool{
+	"beforeSave":   true,
+	"afterSave":    true,
+	"beforeDelete": true,
+	"afterDelete":  true,
+	"beforeFind":   true,
+}
+
+func validTriggerNamesList() []string {
+	var names []string
+	for name := range validTriggerNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type triggerHooksCmd struct {
+	All     bool
+	Trigger *triggerHook
+
+	// Class, TriggerName, and URL let the create/edit/delete/read
+	// subcommands run non-interactively by supplying the
+	// --class/--trigger/--url flags instead of answering the matching
+	// prompt. Yes bypasses the delete confirmation prompt, and Output
+	// selects "text" (default) or "json" for the read/list subcommands,
+	// so scripts can pipe the result into jq.
+	Class       string
+	TriggerName string
+	URL         string
+	Yes         bool
+	Output      string
+}
+
+func readClassName(e *env, class string) (*triggerHook, error) {
+	t := triggerHook{ClassName: class}
+	if t.ClassName == "" {
+		fmt.Fprintf(e.Out, "Please enter the class name: ")
+		fmt.Fscanf(e.In, "%s\n", &t.ClassName)
+	}
+	if t.ClassName == "" {
+		return nil, errors.New("Class name cannot be empty")
+	}
+	return &t, nil
+}
+
+func readTriggerName(e *env, class, trigger string) (*triggerHook, error) {
+	t, err := readClassName(e, class)
+	if err != nil {
+		return nil, err
+	}
+
+	t.TriggerName = trigger
+	if t.TriggerName == "" {
+		fmt.Fprintf(e.Out, "Please enter the trigger name (%s): ", strings.Join(validTriggerNamesList(), ", "))
+		fmt.Fscanf(e.In, "%s\n", &t.TriggerName)
+	}
+	if !validTriggerNames[t.TriggerName] {
+		return nil, fmt.Errorf(
+			"Trigger name must be one of: %s",
+			strings.Join(validTriggerNamesList(), ", "),
+		)
+	}
+	return t, nil
+}
+
+func readTriggerParams(e *env, class, trigger, urlStr string) (*triggerHook, error) {
+	t, err := readTriggerName(e, class, trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	t.URL = urlStr
+	if t.URL == "" {
+		fmt.Fprint(e.Out, "URL: https://")
+		fmt.Fscanf(e.In, "%s\n", &t.URL)
+		t.URL = "https://" + t.URL
+	}
+	if err := validateURL(t.URL); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+const defaultTriggersURL = "/1/hooks/triggers"
+
+func (h *triggerHooksCmd) triggerHooksCreate(e *env, ctx *context) error {
+	params, err := readTriggerParams(e, h.Class, h.TriggerName, h.URL)
+	if err != nil {
+		return err
+	}
+	var res triggerHook
+	triggersURL, err := url.Parse(defaultTriggersURL)
+	if err != nil {
+		return err
+	}
+	_, err = e.ParseAPIClient.Post(triggersURL, params, &res)
+	if err != nil {
+		return err
+	}
+	if res.Warning != "" {
+		fmt.Fprintf(e.Err, "WARNING: %s\n", res.Warning)
+	}
+
+	fmt.Fprintf(e.Out,
+		"Successfully created a webhook trigger %q for class %q pointing to %q\n",
+		res.TriggerName,
+		res.ClassName,
+		res.URL,
+	)
+	return nil
+}
+
+func (h *triggerHooksCmd) triggerHooksRead(e *env, ctx *context) error {
+	u := defaultTriggersURL
+	var trigger *triggerHook
+	if !h.All {
+		t, err := readTriggerName(e, h.Class, h.TriggerName)
+		if err != nil {
+			return err
+		}
+		trigger = t
+		u = path.Join(u, trigger.ClassName, trigger.TriggerName)
+	}
+	triggersURL, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+
+	var res struct {
+		Results []*triggerHook `json:"results,omitempty"`
+	}
+	_, err = e.ParseAPIClient.Get(triggersURL, &res)
+	if err != nil {
+		return err
+	}
+
+	if h.Output == "json" {
+		enc := json.NewEncoder(e.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res.Results)
+	}
+
+	var output []string
+	for _, trigger := range res.Results {
+		output = append(output, trigger.String())
+	}
+	sort.Strings(output)
+
+	if h.All {
+		fmt.Fprintln(e.Out, "The following cloudcode or webhook triggers are associated with this app:")
+	} else {
+		if len(output) == 1 {
+			fmt.Fprintf(e.Out, "You have one trigger named: %q for class: %q\n", trigger.TriggerName, trigger.ClassName)
+		} else {
+			fmt.Fprintf(e.Out, "The following triggers named: %q for class: %q are associated with your app:\n", trigger.TriggerName, trigger.ClassName)
+		}
+	}
+	fmt.Fprintln(e.Out, strings.Join(output, "\n"))
+	return nil
+}
+
+func (h *triggerHooksCmd) triggerHooksUpdate(e *env, ctx *context) error {
+	params, err := readTriggerParams(e, h.Class, h.TriggerName, h.URL)
+	if err != nil {
+		return err
+	}
+	var res triggerHook
+	triggersURL, err := url.Parse(path.Join(defaultTriggersURL, params.ClassName, params.TriggerName))
+	if err != nil {
+		return err
+	}
+
+	_, err = e.ParseAPIClient.Put(triggersURL, &triggerHook{URL: params.URL}, &res)
+	if err != nil {
+		return err
+	}
+	if res.Warning != "" {
+		fmt.Fprintf(e.Err, "WARNING: %s\n", res.Warning)
+	}
+
+	fmt.Fprintf(e.Out,
+		"Successfully update the webhook trigger %q for class %q to point to %q\n",
+		res.TriggerName,
+		res.ClassName,
+		res.URL,
+	)
+	return nil
+}
+
+func (h *triggerHooksCmd) triggerHooksDelete(e *env, ctx *context) error {
+	params, err := readTriggerName(e, h.Class, h.TriggerName)
+	if err != nil {
+		return err
+	}
+	triggersURL, err := url.Parse(path.Join(defaultTriggersURL, params.ClassName, params.TriggerName))
+	if err != nil {
+		return err
+	}
+
+	confirmMessage := fmt.Sprintf(
+		"Are you sure you want to delete webhook trigger: %q for class %q (y/n): ",
+		params.TriggerName,
+		params.ClassName,
+	)
+
+	var res triggerHook
+	if h.Yes || getConfirmation(confirmMessage, e) {
+		_, err = e.ParseAPIClient.Put(triggersURL, map[string]interface{}{"__op": "Delete"}, &res)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.Out, "Successfully deleted webhook trigger %q for class %q\n", params.TriggerName, params.ClassName)
+		if res.TriggerName != "" {
+			fmt.Fprintf(e.Out, "Trigger %q defined in cloud code will be used henceforth\n", res.TriggerName)
+		}
+	}
+
+	return nil
+}
+
+func (h *triggerHooksCmd) triggerHooks(e *env, c *context) error {
+	hp := *h
+	hp.All = true
+	return hp.triggerHooksRead(e, c)
+}
+
+func newTriggerHooksCmd(e *env) *cobra.Command {
+	var h triggerHooksCmd
+
+	c := &cobra.Command{
+		Use:   "triggers",
+		Short: "List cloud code triggers and trigger webhooks",
+		Long:  "List cloud code triggers and trigger webhooks",
+		Run:   runWithClient(e, h.triggerHooks),
+	}
+	c.Flags().StringVar(&h.Output, "output", "text", `output format: "text" or "json"`)
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a trigger webhook",
+		Long:  "Create a trigger webhook",
+		Run:   runWithClient(e, h.triggerHooksCreate),
+	}
+	createCmd.Flags().StringVar(&h.Class, "class", "", "the class name")
+	createCmd.Flags().StringVar(&h.TriggerName, "trigger", "", "the trigger name")
+	createCmd.Flags().StringVar(&h.URL, "url", "", "the webhook URL")
+	c.AddCommand(createCmd)
+
+	changeCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the URL of a trigger webhook",
+		Long:  "Edit the URL of a trigger webhook",
+		Run:   runWithClient(e, h.triggerHooksUpdate),
+	}
+	changeCmd.Flags().StringVar(&h.Class, "class", "", "the class name")
+	changeCmd.Flags().StringVar(&h.TriggerName, "trigger", "", "the trigger name")
+	changeCmd.Flags().StringVar(&h.URL, "url", "", "the webhook URL")
+	c.AddCommand(changeCmd)
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a trigger webhook",
+		Long:  "Delete a trigger webhook",
+		Run:   runWithClient(e, h.triggerHooksDelete),
+	}
+	deleteCmd.Flags().StringVar(&h.Class, "class", "", "the class name")
+	deleteCmd.Flags().StringVar(&h.TriggerName, "trigger", "", "the trigger name")
+	deleteCmd.Flags().BoolVar(&h.Yes, "yes", false, "skip the delete confirmation prompt")
+	c.AddCommand(deleteCmd)
+
+	readCmd := &cobra.Command{
+		Use:   "read",
+		Short: "Show a single trigger webhook",
+		Long:  "Show a single trigger webhook",
+		Run:   runWithClient(e, h.triggerHooksRead),
+	}
+	readCmd.Flags().StringVar(&h.Class, "class", "", "the class name")
+	readCmd.Flags().StringVar(&h.TriggerName, "trigger", "", "the trigger name")
+	readCmd.Flags().StringVar(&h.Output, "output", "text", `output format: "text" or "json"`)
+	c.AddCommand(readCmd)
+
+	return c
+}